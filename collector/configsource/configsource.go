@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configsource replaces the extension's hard-coded SSM/file config
+// branch with a first-class, pluggable resolver. A Resolver tries a list of
+// Provider implementations in order and merges the YAML documents they
+// return, so a user can bake a base config into their layer and overlay
+// per-environment settings from Parameter Store, AppConfig, Secrets Manager,
+// S3, a local file, an HTTP(S) URL, or an inline env var.
+package configsource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Provider fetches a single collector config document. Name identifies the
+// provider in logs and in Resolver.Watch events; URI is the scheme-qualified
+// location the provider was constructed from (e.g. "ssm://my-param").
+type Provider interface {
+	Name() string
+	URI() string
+	Fetch(ctx context.Context) (io.Reader, string, error)
+}
+
+// WatchingProvider is implemented by providers that can detect when their
+// backing config changes without being polled from scratch, e.g. SSMProvider,
+// which compares the parameter's Version. Resolver.Watch uses it
+// to push updates onto the channel it returns.
+type WatchingProvider interface {
+	Provider
+	// Changed reports whether the config has changed since the last call,
+	// along with an opaque token identifying the observed revision.
+	Changed(ctx context.Context) (changed bool, revision string, err error)
+}
+
+// Resolver tries its providers in registration order and merges the YAML
+// documents they return into a single effective config.
+type Resolver struct {
+	providers []Provider
+}
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithProvider registers a Provider with the resolver. Providers are tried,
+// and their output merged, in the order they are added.
+func WithProvider(p Provider) Option {
+	return func(r *Resolver) { r.providers = append(r.providers, p) }
+}
+
+// NewResolver builds a Resolver from the given options.
+func NewResolver(opts ...Option) *Resolver {
+	r := &Resolver{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve fetches the config document from every registered provider and
+// merges them in order, so that values from a later provider (e.g. an SSM
+// override) take precedence over a base document from an earlier one (e.g. a
+// file baked into the layer). It returns an error only if every provider
+// fails. A provider failing while at least one other succeeds does not fail
+// Resolve - that one missing override shouldn't take down the whole config -
+// but its error is returned alongside the merged config so the caller can log
+// it instead of it disappearing silently.
+func (r *Resolver) Resolve(ctx context.Context) ([]byte, []error, error) {
+	if len(r.providers) == 0 {
+		return nil, nil, fmt.Errorf("configsource: no providers registered")
+	}
+
+	var merged map[string]interface{}
+	var skipped []error
+	fetched := 0
+
+	for _, p := range r.providers {
+		reader, _, err := p.Fetch(ctx)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("%s (%s): %w", p.Name(), p.URI(), err))
+			continue
+		}
+
+		raw, err := io.ReadAll(reader)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("%s (%s): reading: %w", p.Name(), p.URI(), err))
+			continue
+		}
+
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(raw, &doc); err != nil {
+			skipped = append(skipped, fmt.Errorf("%s (%s): parsing yaml: %w", p.Name(), p.URI(), err))
+			continue
+		}
+		merged = mergeMaps(merged, doc)
+		fetched++
+	}
+
+	if fetched == 0 {
+		return nil, nil, fmt.Errorf("configsource: all providers failed, last error: %w", skipped[len(skipped)-1])
+	}
+
+	configYAML, err := yaml.Marshal(merged)
+	return configYAML, skipped, err
+}
+
+// mergeMaps deep-merges override on top of base, recursing into nested maps
+// and otherwise letting override win.
+func mergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	if base == nil {
+		return override
+	}
+	for k, v := range override {
+		if existing, ok := base[k]; ok {
+			existingMap, existingIsMap := existing.(map[string]interface{})
+			overrideMap, overrideIsMap := v.(map[string]interface{})
+			if existingIsMap && overrideIsMap {
+				base[k] = mergeMaps(existingMap, overrideMap)
+				continue
+			}
+		}
+		base[k] = v
+	}
+	return base
+}
+
+// Event describes a config change detected by Resolver.Watch.
+type Event struct {
+	// Provider is the Name() of the provider whose content changed.
+	Provider string
+	// Err is set when polling a provider for changes failed.
+	Err error
+}
+
+// Watch starts polling every WatchingProvider registered with the resolver
+// and returns a channel that receives an Event each time one of them reports
+// a change, until ctx is cancelled. Providers that don't implement
+// WatchingProvider are ignored; callers that only care about a one-shot
+// config should use Resolve instead.
+func (r *Resolver) Watch(ctx context.Context, interval func() <-chan struct{}) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		tick := interval()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-tick:
+				if !ok {
+					return
+				}
+				for _, p := range r.providers {
+					wp, ok := p.(WatchingProvider)
+					if !ok {
+						continue
+					}
+					changed, _, err := wp.Changed(ctx)
+					if err != nil {
+						events <- Event{Provider: p.Name(), Err: err}
+						continue
+					}
+					if changed {
+						events <- Event{Provider: p.Name()}
+					}
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// readerFromString is a small helper shared by providers that already hold
+// their config document in memory (env var, SSM, Secrets Manager, ...).
+func readerFromString(s string) io.Reader {
+	return bytes.NewBufferString(s)
+}
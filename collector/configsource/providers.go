@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// ssmGetParameterAPI is the subset of *ssm.Client used by SSMProvider, broken
+// out so tests can supply a fake without standing up real AWS credentials.
+type ssmGetParameterAPI interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// SSMProvider fetches a collector config document from an SSM Parameter
+// Store parameter. This is the provider that backs the extension's original
+// OPENTELEMETRY_SSM_PARAMETER_NAME behavior.
+type SSMProvider struct {
+	client        ssmGetParameterAPI
+	parameterName string
+
+	lastVersion int64
+}
+
+// NewSSMProvider returns a Provider reading parameterName via client.
+func NewSSMProvider(client ssmGetParameterAPI, parameterName string) *SSMProvider {
+	return &SSMProvider{client: client, parameterName: parameterName}
+}
+
+func (p *SSMProvider) Name() string { return "ssm" }
+func (p *SSMProvider) URI() string  { return "ssm://" + p.parameterName }
+
+func (p *SSMProvider) Fetch(ctx context.Context) (io.Reader, string, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name: aws.String(p.parameterName),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	p.lastVersion = out.Parameter.Version
+	return readerFromString(aws.ToString(out.Parameter.Value)), fmt.Sprintf("%d", out.Parameter.Version), nil
+}
+
+// Changed implements WatchingProvider by comparing the parameter's Version
+// against the value last observed by Fetch.
+func (p *SSMProvider) Changed(ctx context.Context) (bool, string, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name: aws.String(p.parameterName),
+	})
+	if err != nil {
+		return false, "", err
+	}
+	changed := out.Parameter.Version != p.lastVersion
+	p.lastVersion = out.Parameter.Version
+	return changed, fmt.Sprintf("%d", out.Parameter.Version), nil
+}
+
+// AppConfigProvider fetches a collector config document from an AWS
+// AppConfig configuration profile, which supports staged rollouts and
+// validators that a plain SSM parameter does not.
+type AppConfigProvider struct {
+	client                 *appconfigdata.Client
+	application, env, prof string
+	sessionToken           *string
+}
+
+// NewAppConfigProvider returns a Provider reading the given application /
+// environment / configuration profile via client.
+func NewAppConfigProvider(client *appconfigdata.Client, application, environment, profile string) *AppConfigProvider {
+	return &AppConfigProvider{client: client, application: application, env: environment, prof: profile}
+}
+
+func (p *AppConfigProvider) Name() string { return "appconfig" }
+func (p *AppConfigProvider) URI() string {
+	return fmt.Sprintf("appconfig://%s/%s/%s", p.application, p.env, p.prof)
+}
+
+func (p *AppConfigProvider) Fetch(ctx context.Context) (io.Reader, string, error) {
+	if p.sessionToken == nil {
+		session, err := p.client.StartConfigurationSession(ctx, &appconfigdata.StartConfigurationSessionInput{
+			ApplicationIdentifier:          aws.String(p.application),
+			EnvironmentIdentifier:          aws.String(p.env),
+			ConfigurationProfileIdentifier: aws.String(p.prof),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		p.sessionToken = session.InitialConfigurationToken
+	}
+
+	out, err := p.client.GetLatestConfiguration(ctx, &appconfigdata.GetLatestConfigurationInput{
+		ConfigurationToken: p.sessionToken,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	p.sessionToken = out.NextPollConfigurationToken
+	return bytes.NewReader(out.Configuration), "", nil
+}
+
+// SecretsManagerProvider fetches a collector config document stored as a
+// Secrets Manager secret value.
+type SecretsManagerProvider struct {
+	client   *secretsmanager.Client
+	secretID string
+}
+
+// NewSecretsManagerProvider returns a Provider reading secretID via client.
+func NewSecretsManagerProvider(client *secretsmanager.Client, secretID string) *SecretsManagerProvider {
+	return &SecretsManagerProvider{client: client, secretID: secretID}
+}
+
+func (p *SecretsManagerProvider) Name() string { return "secretsmanager" }
+func (p *SecretsManagerProvider) URI() string  { return "secretsmanager://" + p.secretID }
+
+func (p *SecretsManagerProvider) Fetch(ctx context.Context) (io.Reader, string, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(p.secretID),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return readerFromString(aws.ToString(out.SecretString)), aws.ToString(out.VersionId), nil
+}
+
+// S3Provider fetches a collector config document from an object in S3.
+type S3Provider struct {
+	client      *s3.Client
+	bucket, key string
+}
+
+// NewS3Provider returns a Provider reading bucket/key via client.
+func NewS3Provider(client *s3.Client, bucket, key string) *S3Provider {
+	return &S3Provider{client: client, bucket: bucket, key: key}
+}
+
+func (p *S3Provider) Name() string { return "s3" }
+func (p *S3Provider) URI() string  { return fmt.Sprintf("s3://%s/%s", p.bucket, p.key) }
+
+func (p *S3Provider) Fetch(ctx context.Context) (io.Reader, string, error) {
+	out, err := p.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(p.key),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	defer out.Body.Close()
+	raw, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return readerFromString(string(raw)), aws.ToString(out.ETag), nil
+}
+
+// FileProvider fetches a collector config document from a file on the
+// Lambda filesystem, e.g. one mounted from a layer.
+type FileProvider struct {
+	path string
+}
+
+// NewFileProvider returns a Provider reading path.
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+func (p *FileProvider) Name() string { return "file" }
+func (p *FileProvider) URI() string  { return "file://" + p.path }
+
+func (p *FileProvider) Fetch(context.Context) (io.Reader, string, error) {
+	raw, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, "", err
+	}
+	return readerFromString(string(raw)), "", nil
+}
+
+// HTTPProvider fetches a collector config document from an HTTP(S) URL.
+type HTTPProvider struct {
+	client *http.Client
+	url    string
+}
+
+// NewHTTPProvider returns a Provider reading url with client. If client is
+// nil, http.DefaultClient is used.
+func NewHTTPProvider(client *http.Client, url string) *HTTPProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPProvider{client: client, url: url}
+}
+
+func (p *HTTPProvider) Name() string { return "http" }
+func (p *HTTPProvider) URI() string  { return p.url }
+
+func (p *HTTPProvider) Fetch(ctx context.Context) (io.Reader, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, p.url)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return readerFromString(string(raw)), resp.Header.Get("ETag"), nil
+}
+
+// EnvProvider fetches a collector config document inlined directly into an
+// environment variable, useful for small configs or local testing without
+// any AWS dependency.
+type EnvProvider struct {
+	envVar string
+}
+
+// NewEnvProvider returns a Provider reading the YAML document from envVar.
+func NewEnvProvider(envVar string) *EnvProvider {
+	return &EnvProvider{envVar: envVar}
+}
+
+func (p *EnvProvider) Name() string { return "env" }
+func (p *EnvProvider) URI() string  { return "env://" + p.envVar }
+
+func (p *EnvProvider) Fetch(context.Context) (io.Reader, string, error) {
+	val, ok := os.LookupEnv(p.envVar)
+	if !ok {
+		return nil, "", fmt.Errorf("environment variable %s is not set", p.envVar)
+	}
+	return readerFromString(val), "", nil
+}
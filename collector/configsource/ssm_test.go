@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// fakeSSMClient returns the next value from versions on every call to
+// GetParameter, repeating the last one once exhausted.
+type fakeSSMClient struct {
+	versions []int64
+	calls    int
+}
+
+func (f *fakeSSMClient) GetParameter(_ context.Context, _ *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	i := f.calls
+	if i >= len(f.versions) {
+		i = len(f.versions) - 1
+	}
+	f.calls++
+	version := f.versions[i]
+	return &ssm.GetParameterOutput{
+		Parameter: &types.Parameter{
+			Name:    aws.String("my-param"),
+			Value:   aws.String("receivers: {}"),
+			Version: version,
+		},
+	}, nil
+}
+
+func TestSSMProviderChanged(t *testing.T) {
+	fake := &fakeSSMClient{versions: []int64{1, 1, 2, 2, 3}}
+	provider := NewSSMProvider(fake, "my-param")
+
+	if _, _, err := provider.Fetch(context.Background()); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	wantChanged := []bool{false, true, false, true}
+	for i, want := range wantChanged {
+		changed, _, err := provider.Changed(context.Background())
+		if err != nil {
+			t.Fatalf("Changed[%d]: %v", i, err)
+		}
+		if changed != want {
+			t.Errorf("Changed[%d] = %v, want %v", i, changed, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// ProvidersFromURIs builds one Provider per comma-separated URI in uris, in
+// order, so a user can mix a base config baked into the layer with
+// per-environment overrides in Parameter Store, e.g.
+// "file:///opt/base.yaml,ssm://my-param". Recognized schemes are ssm,
+// appconfig, secretsmanager, s3, file, http(s), and env.
+func ProvidersFromURIs(cfg aws.Config, uris string) ([]Provider, error) {
+	var providers []Provider
+	for _, uri := range strings.Split(uris, ",") {
+		uri = strings.TrimSpace(uri)
+		if uri == "" {
+			continue
+		}
+		p, err := providerFromURI(cfg, uri)
+		if err != nil {
+			return nil, fmt.Errorf("configsource: %s: %w", uri, err)
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
+
+func providerFromURI(cfg aws.Config, uri string) (Provider, error) {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		return nil, fmt.Errorf("missing scheme, expected scheme://path")
+	}
+
+	switch scheme {
+	case "ssm":
+		return NewSSMProvider(ssm.NewFromConfig(cfg), rest), nil
+	case "appconfig":
+		parts := strings.SplitN(rest, "/", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("expected appconfig://application/environment/profile")
+		}
+		return NewAppConfigProvider(appconfigdata.NewFromConfig(cfg), parts[0], parts[1], parts[2]), nil
+	case "secretsmanager":
+		return NewSecretsManagerProvider(secretsmanager.NewFromConfig(cfg), rest), nil
+	case "s3":
+		bucket, key, ok := strings.Cut(rest, "/")
+		if !ok {
+			return nil, fmt.Errorf("expected s3://bucket/key")
+		}
+		return NewS3Provider(s3.NewFromConfig(cfg), bucket, key), nil
+	case "file":
+		return NewFileProvider("/" + strings.TrimPrefix(rest, "/")), nil
+	case "http", "https":
+		return NewHTTPProvider(nil, uri), nil
+	case "env":
+		return NewEnvProvider(rest), nil
+	default:
+		return nil, fmt.Errorf("unrecognized scheme %q", scheme)
+	}
+}
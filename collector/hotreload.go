@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/configsource"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+)
+
+// EnvSSMRefreshSeconds gates the SSM hot-reload poll loop in processEvents.
+// The default, 0, disables polling so updating the parameter still requires
+// waiting for the execution environment to recycle, matching the extension's
+// historical behavior.
+const EnvSSMRefreshSeconds = "OPENTELEMETRY_SSM_REFRESH_SECONDS"
+
+// ssmRefreshInterval reads EnvSSMRefreshSeconds, returning 0 (disabled) if
+// it's unset or not a positive integer.
+func ssmRefreshInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv(EnvSSMRefreshSeconds))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// collectorHandle is the subset of *Collector's interface the hot-reload and
+// OpAMP apply paths need. Breaking it out lets tests substitute a fake
+// collector instead of starting a real pipeline.
+type collectorHandle interface {
+	Start(ctx context.Context) error
+	Stop()
+}
+
+// newCollector is indirected so tests can substitute a fake collectorHandle
+// for reloadIfConfigChanged and the OpAMP apply callback without starting a
+// real pipeline.
+var newCollector = func(factories otelcol.Factories, configPath string) collectorHandle {
+	return NewCollector(factories, configPath)
+}
+
+// collectorRef is the single mutex-guarded handle to "the current collector"
+// shared by the SSM hot-reload poll in processEvents and the OpAMP apply
+// callback. Without a shared, lock-guarded handle the two paths raced: each
+// held its own notion of the current collector and could stop or replace the
+// other's instance out from under it.
+type collectorRef struct {
+	mu  sync.Mutex
+	cur collectorHandle
+}
+
+// current returns the collector currently in use.
+func (r *collectorRef) current() collectorHandle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cur
+}
+
+// stop stops whatever collector is current, guarding against a concurrent
+// swap replacing it mid-stop.
+func (r *collectorRef) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cur.Stop() // TODO: handle return values
+}
+
+// swap stops the current collector, starts next, and installs it as current,
+// all under the same lock the hot-reload and OpAMP paths share.
+func (r *collectorRef) swap(ctx context.Context, next collectorHandle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cur.Stop() // TODO: handle return values
+	if err := next.Start(ctx); err != nil {
+		return err
+	}
+	r.cur = next
+	return nil
+}
+
+// reloadIfConfigChanged checks whether the SSM parameter backing provider has
+// moved on to a new Version since it was last read, and if so tears down
+// ref's current collector and installs a replacement built against the new
+// config. It reports whether a reload happened.
+func reloadIfConfigChanged(ctx context.Context, provider *configsource.SSMProvider, factories otelcol.Factories, ref *collectorRef, logger *zap.Logger) (bool, error) {
+	changed, _, err := provider.Changed(ctx)
+	if err != nil {
+		return false, fmt.Errorf("checking ssm parameter version: %w", err)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	reader, _, err := provider.Fetch(ctx)
+	if err != nil {
+		return false, fmt.Errorf("fetching updated ssm parameter: %w", err)
+	}
+	configYAML, err := io.ReadAll(reader)
+	if err != nil {
+		return false, fmt.Errorf("reading updated ssm parameter: %w", err)
+	}
+
+	configYAML, err = injectLambdaResourceAttrs(ctx, factories, configYAML, logger)
+	if err != nil {
+		return false, fmt.Errorf("injecting lambda resource attributes: %w", err)
+	}
+
+	path := "/tmp/resolved_collector.yml"
+	if err := os.WriteFile(path, configYAML, 0o600); err != nil {
+		return false, fmt.Errorf("writing updated config: %w", err)
+	}
+
+	if err := ref.swap(ctx, newCollector(factories, path)); err != nil {
+		return false, fmt.Errorf("starting collector with updated config: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/configsource"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+)
+
+// fakeSSMClient returns the next value from versions on every call to
+// GetParameter, repeating the last one once exhausted.
+type fakeSSMClient struct {
+	versions []int64
+	configs  []string
+	calls    int
+}
+
+func (f *fakeSSMClient) GetParameter(_ context.Context, _ *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	i := f.calls
+	if i >= len(f.versions) {
+		i = len(f.versions) - 1
+	}
+	f.calls++
+	return &ssm.GetParameterOutput{
+		Parameter: &types.Parameter{
+			Name:    aws.String("my-param"),
+			Value:   aws.String(f.configs[i]),
+			Version: f.versions[i],
+		},
+	}, nil
+}
+
+// fakeCollector records whether it was started/stopped, standing in for a
+// real *Collector so the hot-reload restart path can be exercised without
+// starting actual collector pipelines.
+type fakeCollector struct {
+	configPath string
+	started    bool
+	stopped    bool
+}
+
+func (f *fakeCollector) Start(context.Context) error {
+	f.started = true
+	return nil
+}
+
+func (f *fakeCollector) Stop() {
+	f.stopped = true
+}
+
+func TestReloadIfConfigChangedRestartsCollectorWithNewPipeline(t *testing.T) {
+	fake := &fakeSSMClient{
+		versions: []int64{1, 2},
+		configs:  []string{"receivers: {}", "receivers:\n  otlp:\n"},
+	}
+	provider := configsource.NewSSMProvider(fake, "my-param")
+	if _, _, err := provider.Fetch(context.Background()); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	var built []*fakeCollector
+	origNewCollector := newCollector
+	newCollector = func(_ otelcol.Factories, configPath string) collectorHandle {
+		c := &fakeCollector{configPath: configPath}
+		built = append(built, c)
+		return c
+	}
+	defer func() { newCollector = origNewCollector }()
+
+	original := &fakeCollector{configPath: "/tmp/original.yml"}
+	ref := &collectorRef{cur: original}
+
+	reloaded, err := reloadIfConfigChanged(context.Background(), provider, otelcol.Factories{}, ref, zap.NewNop())
+	if err != nil {
+		t.Fatalf("reloadIfConfigChanged: %v", err)
+	}
+	if !reloaded {
+		t.Fatal("reloadIfConfigChanged reported no reload despite a new SSM version")
+	}
+
+	if !original.stopped {
+		t.Error("old collector was not stopped")
+	}
+	if len(built) != 1 || !built[0].started {
+		t.Fatalf("new collector was not started: %+v", built)
+	}
+	if ref.current() != built[0] {
+		t.Error("collectorRef was not swapped to the newly built collector")
+	}
+
+	written, err := os.ReadFile(built[0].configPath)
+	if err != nil {
+		t.Fatalf("reading written config: %v", err)
+	}
+	if got := string(written); got != fake.configs[1] {
+		t.Errorf("written config = %q, want the updated pipeline %q", got, fake.configs[1])
+	}
+}
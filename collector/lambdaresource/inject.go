@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdaresource
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// processorName is the key the Lambda resource processor is registered
+// under. Users who already configure their own "resource" processor keep it;
+// this one is added alongside so both run.
+const processorName = "resource/lambda"
+
+// Inject parses configYAML, adds a resource processor that upserts attrs,
+// and prepends it to every pipeline's processor list so the attributes are
+// present on every signal the pipeline emits, however the user configured
+// their own processors. A pipeline that already references processorName
+// keeps its processor list as is; the processorName definition itself is
+// always replaced with one built from attrs, and the config is re-encoded.
+func Inject(configYAML []byte, attrs map[string]string) ([]byte, error) {
+	var cfg map[string]interface{}
+	if err := yaml.Unmarshal(configYAML, &cfg); err != nil {
+		return nil, fmt.Errorf("lambdaresource: parsing collector config: %w", err)
+	}
+
+	setProcessor(cfg, attrs)
+
+	service, ok := cfg["service"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("lambdaresource: collector config has no service section")
+	}
+	pipelines, ok := service["pipelines"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("lambdaresource: collector config has no service.pipelines section")
+	}
+
+	for name, p := range pipelines {
+		pipeline, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pipeline["processors"] = prependProcessor(toStringSlice(pipeline["processors"]))
+		pipelines[name] = pipeline
+	}
+
+	return yaml.Marshal(cfg)
+}
+
+func setProcessor(cfg map[string]interface{}, attrs map[string]string) {
+	processors, ok := cfg["processors"].(map[string]interface{})
+	if !ok {
+		processors = map[string]interface{}{}
+	}
+
+	var attributes []map[string]interface{}
+	for k, v := range attrs {
+		attributes = append(attributes, map[string]interface{}{
+			"key":    k,
+			"value":  v,
+			"action": "upsert",
+		})
+	}
+
+	processors[processorName] = map[string]interface{}{"attributes": attributes}
+	cfg["processors"] = processors
+}
+
+func prependProcessor(existing []string) []string {
+	for _, p := range existing {
+		if p == processorName {
+			return existing
+		}
+	}
+	return append([]string{processorName}, existing...)
+}
+
+func toStringSlice(v interface{}) []string {
+	raw, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(raw))
+	for _, r := range raw {
+		if s, ok := r.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lambdaresource discovers the OpenTelemetry semantic-convention
+// resource attributes for the Lambda environment the extension is running
+// in, so users don't have to hand-write them into their collector config.
+package lambdaresource
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// Version is reported as the telemetry.sdk.version resource attribute.
+const Version = "0.1.0"
+
+// Detector builds the set of resource attributes for the current Lambda
+// environment. Construct one with NewDetector.
+type Detector struct {
+	getenv    func(string) string
+	accountID func(ctx context.Context) (string, error)
+}
+
+// Option configures a Detector.
+type Option func(*Detector)
+
+// withGetenv overrides the environment lookup function, used by tests.
+func withGetenv(getenv func(string) string) Option {
+	return func(d *Detector) { d.getenv = getenv }
+}
+
+// withAccountID overrides the account id resolver, used by tests.
+func withAccountID(accountID func(ctx context.Context) (string, error)) Option {
+	return func(d *Detector) { d.accountID = accountID }
+}
+
+// NewDetector builds a Detector from the given options.
+func NewDetector(opts ...Option) *Detector {
+	d := &Detector{getenv: os.Getenv, accountID: stsAccountID}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Detect returns the standard semantic-convention attributes for the Lambda
+// execution environment the extension is running alongside: faas.*,
+// cloud.*, aws.log.*, and telemetry.sdk.*. Values are derived from the
+// Lambda-managed environment variables, except cloud.account.id, which isn't
+// exposed as an environment variable and is instead resolved via STS
+// GetCallerIdentity the same way main.go's functionARN does. Any attribute
+// whose source is unavailable is omitted rather than reported empty.
+func (d *Detector) Detect(ctx context.Context) map[string]string {
+	attrs := map[string]string{
+		"cloud.provider":         "aws",
+		"telemetry.sdk.name":     "opentelemetry",
+		"telemetry.sdk.language": "go",
+		"telemetry.sdk.version":  Version,
+	}
+
+	d.setIfPresent(attrs, "faas.name", "AWS_LAMBDA_FUNCTION_NAME")
+	d.setIfPresent(attrs, "faas.version", "AWS_LAMBDA_FUNCTION_VERSION")
+	d.setIfPresent(attrs, "faas.instance", "AWS_LAMBDA_LOG_STREAM_NAME")
+	d.setIfPresent(attrs, "cloud.region", "AWS_REGION")
+	d.setIfPresent(attrs, "aws.log.group.names", "AWS_LAMBDA_LOG_GROUP_NAME")
+
+	if account, err := d.accountID(ctx); err == nil && account != "" {
+		attrs["cloud.account.id"] = account
+	}
+
+	return attrs
+}
+
+func (d *Detector) setIfPresent(attrs map[string]string, attr, envVar string) {
+	if v := d.getenv(envVar); v != "" {
+		attrs[attr] = v
+	}
+}
+
+var (
+	accountIDMu     sync.Mutex
+	cachedAccountID string
+)
+
+// stsAccountID resolves the account id via STS GetCallerIdentity, the same
+// call main.go's functionARN makes, and caches it for the lifetime of the
+// execution environment since it never changes between invocations handled
+// by the same sandbox. A failed lookup isn't cached, so a transient error
+// (e.g. no network yet while an ENI attaches) doesn't permanently disable
+// cloud.account.id for every later invocation in the sandbox.
+func stsAccountID(ctx context.Context) (string, error) {
+	accountIDMu.Lock()
+	defer accountIDMu.Unlock()
+
+	if cachedAccountID != "" {
+		return cachedAccountID, nil
+	}
+
+	cfg, err := awsConfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	cachedAccountID = aws.ToString(identity.Account)
+	return cachedAccountID, nil
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdaresource
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDetectorDetect(t *testing.T) {
+	env := map[string]string{
+		"AWS_LAMBDA_FUNCTION_NAME": "my-func",
+		"AWS_REGION":               "us-east-1",
+	}
+	d := NewDetector(
+		withGetenv(func(k string) string { return env[k] }),
+		withAccountID(func(context.Context) (string, error) { return "123456789012", nil }),
+	)
+
+	attrs := d.Detect(context.Background())
+
+	want := map[string]string{
+		"faas.name":        "my-func",
+		"cloud.region":     "us-east-1",
+		"cloud.account.id": "123456789012",
+		"cloud.provider":   "aws",
+	}
+	for k, v := range want {
+		if attrs[k] != v {
+			t.Errorf("attrs[%q] = %q, want %q", k, attrs[k], v)
+		}
+	}
+	if _, ok := attrs["faas.version"]; ok {
+		t.Errorf("faas.version should be omitted when AWS_LAMBDA_FUNCTION_VERSION is unset, got %q", attrs["faas.version"])
+	}
+}
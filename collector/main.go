@@ -19,26 +19,65 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
-	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/configsource"
 	"github.com/open-telemetry/opentelemetry-lambda/collector/extension"
 	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdaresource"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/opampclient"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/status"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/telemetry"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol"
 	"go.uber.org/zap"
 )
 
+// resourceProcessorType is the processor type lambdaresource.Inject adds to
+// every pipeline. It's only safe to inject if lambdacomponents.Components()
+// actually registered a factory for it - otherwise every collector start
+// fails with "unknown processor type."
+var resourceProcessorType = component.MustNewType("resource")
+
+// injectLambdaResourceAttrs adds the Lambda resource attribute processor to
+// configYAML via lambdaresource.Inject, guarded on the "resource" processor
+// factory actually being registered in factories. If it isn't, configYAML is
+// returned unchanged and the gap is logged rather than failing every
+// collector start.
+func injectLambdaResourceAttrs(ctx context.Context, factories otelcol.Factories, configYAML []byte, logger *zap.Logger) ([]byte, error) {
+	if _, ok := factories.Processors[resourceProcessorType]; !ok {
+		logger.Warn(`lambdaresource: "resource" processor factory not registered, skipping attribute injection`)
+		return configYAML, nil
+	}
+	return lambdaresource.Inject(configYAML, lambdaresource.NewDetector().Detect(ctx))
+}
+
 var (
 	extensionName   = filepath.Base(os.Args[0]) // extension name has to match the filename
 	extensionClient = extension.NewClient(os.Getenv("AWS_LAMBDA_RUNTIME_API"))
-	logger          = zap.NewExample()
+	logger          *zap.Logger
 	ssmClient       = &ssm.Client{}
 )
 
 func main() {
+	var err error
+	logger, err = telemetry.NewLogger()
+	if err != nil {
+		panic("logger configuration error, " + err.Error())
+	}
+	defer logger.Sync()
+
+	selfInstrumentation, err := telemetry.NewSelfInstrumentation()
+	if err != nil {
+		logger.Fatal("Failed to set up self-instrumentation", zap.Error(err))
+	}
+
 	logger.Debug("Launching OpenTelemetry Lambda extension", zap.String("version", Version))
 	cfg, err := awsConfig.LoadDefaultConfig(context.Background())
 	if err != nil {
@@ -46,75 +85,231 @@ func main() {
 	}
 	ssmClient = ssm.NewFromConfig(cfg)
 
+	statusReporter := status.NewAggregator(logger)
+
 	factories, _ := lambdacomponents.Components()
-	config, err := getSsmConfig()
+	factories = status.WrapFactories(factories, statusReporter)
+	config, ssmProvider, err := resolveConfig(cfg, factories)
 	if err != nil {
-		logger.Error("%s", zap.Field{String: err.Error()})
-		config = getConfig()
+		logger.Fatal("Failed to resolve collector config", zap.Error(err))
 	}
-	collector := NewCollector(factories, config)
+	ref := &collectorRef{cur: NewCollector(factories, config)}
 	ctx, cancel := context.WithCancel(context.Background())
 
-	if err := collector.Start(ctx); err != nil {
-		log.Fatalf("Failed to start the extension: %v", err)
+	for _, name := range componentNames(factories) {
+		statusReporter.ReportStatus(name, status.Starting, nil)
+	}
+	go func() {
+		if err := status.Serve(ctx, statusReporter); err != nil {
+			logger.Warn("status: local status endpoint stopped", zap.Error(err))
+		}
+	}()
+
+	// Individual components report their own OK/RecoverableError transitions
+	// as the collector starts them, via the factories status.WrapFactories
+	// wrapped above; this only covers the collector failing to start at all
+	// (e.g. a config parse error before any component is built).
+	if err := ref.current().Start(ctx); err != nil {
+		statusReporter.ReportStatus("collector", status.PermanentError, err)
+		logger.Fatal("Failed to start the extension", zap.Error(err))
+	}
+
+	if endpoint := os.Getenv(opampclient.EnvEndpoint); endpoint != "" {
+		opamp, err := startOpAMPClient(ctx, endpoint, factories, ref)
+		if err != nil {
+			// The OpAMP server is unreachable (e.g. at cold start in a VPC
+			// with no route to it yet); fall back to the config already
+			// loaded above from SSM/file rather than failing the extension.
+			logger.Warn("opamp: failed to start, falling back to local config", zap.Error(err))
+		} else {
+			defer opamp.Stop(context.Background())
+		}
 	}
 
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
 	go func() {
 		s := <-sigs
+		_, span := selfInstrumentation.StartShutdown(ctx)
 		cancel()
-		logger.Debug(fmt.Sprintf("Received", s))
+		logger.Debug("Received signal", zap.String("signal", s.String()))
 		logger.Debug("Exiting")
+		span.End()
+		if err := selfInstrumentation.Shutdown(context.Background()); err != nil {
+			logger.Warn("self-instrumentation: failed to shut down cleanly", zap.Error(err))
+		}
 	}()
 
-	res, err := extensionClient.Register(ctx, extensionName)
+	registerCtx, registerSpan := selfInstrumentation.StartRegister(ctx)
+	res, err := extensionClient.Register(registerCtx, extensionName)
+	registerSpan.End()
 	if err != nil {
-		log.Fatalf("Cannot register extension: %v", err)
+		logger.Fatal("Cannot register extension", zap.Error(err))
 	}
 
 	logger.Debug("Register ", zap.String("response :", prettyPrint(res)))
+
+	subscriber := status.NewSubscriber(os.Getenv("AWS_LAMBDA_RUNTIME_API"), extensionClient.ExtensionID)
+	if err := subscriber.Subscribe(ctx, status.TelemetryDestination); err != nil {
+		logger.Warn("status: failed to subscribe to telemetry api", zap.Error(err))
+	}
 	// Will block until shutdown event is received or cancelled via the context.
-	processEvents(ctx, collector)
+	processEvents(ctx, ref, factories, ssmProvider, statusReporter, selfInstrumentation)
+}
+
+// componentNames lists every receiver, processor, exporter, and connector
+// type registered through lambdacomponents.Components(), used to seed and
+// update the status subsystem without the collector itself having to know
+// about it.
+func componentNames(factories otelcol.Factories) []string {
+	var names []string
+	for t := range factories.Receivers {
+		names = append(names, "receiver/"+t.String())
+	}
+	for t := range factories.Processors {
+		names = append(names, "processor/"+t.String())
+	}
+	for t := range factories.Exporters {
+		names = append(names, "exporter/"+t.String())
+	}
+	for t := range factories.Connectors {
+		names = append(names, "connector/"+t.String())
+	}
+	return names
 }
 
-func getSsmConfig() (string, error) {
-	output, err := ssmClient.GetParameter(context.Background(), &ssm.GetParameterInput{
-		Name: aws.String(os.Getenv("OPENTELEMETRY_SSM_PARAMETER_NAME")),
-	})
+// resolveConfig builds a configsource.Resolver and fetches the effective
+// collector config, writing it to a file on disk so it can be handed to
+// NewCollector the same way a mounted file always has been.
+//
+// OPENTELEMETRY_CONFIG_SOURCES, if set, takes a comma-separated list of
+// provider URIs (e.g. "file:///opt/base.yaml,ssm://my-param") tried and
+// merged in order, letting a base config baked into the layer be overlaid
+// with per-environment overrides. Otherwise the resolver falls back to the
+// extension's original behavior: OPENTELEMETRY_SSM_PARAMETER_NAME if set,
+// else the local config file.
+func resolveConfig(cfg aws.Config, factories otelcol.Factories) (string, *configsource.SSMProvider, error) {
+	resolver, ssmProvider, err := buildResolver(cfg)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	path := "/tmp/" + "ssm_collector.yml"
-	file, err := os.Create(path)
+
+	configYAML, skipped, err := resolver.Resolve(context.Background())
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	_, err = file.WriteString(*output.Parameter.Value)
+	for _, skippedErr := range skipped {
+		logger.Warn("config source skipped", zap.Error(skippedErr))
+	}
+
+	configYAML, err = injectLambdaResourceAttrs(context.Background(), factories, configYAML, logger)
 	if err != nil {
-		return "", err
+		return "", nil, fmt.Errorf("injecting lambda resource attributes: %w", err)
+	}
+
+	path := "/tmp/resolved_collector.yml"
+	if err := os.WriteFile(path, configYAML, 0o600); err != nil {
+		return "", nil, fmt.Errorf("writing resolved config: %w", err)
 	}
-	return path, nil
+	return path, ssmProvider, nil
 }
 
-func getConfig() string {
+// buildResolver also returns the SSM provider it registered from
+// OPENTELEMETRY_SSM_PARAMETER_NAME, if any, so the caller can reuse it to
+// detect parameter changes for hot-reload without a second,
+// independently-configured SSM client. When OPENTELEMETRY_CONFIG_SOURCES is
+// set it returns nil, even if one of those URIs is ssm://.
+func buildResolver(cfg aws.Config) (*configsource.Resolver, *configsource.SSMProvider, error) {
+	if uris := os.Getenv("OPENTELEMETRY_CONFIG_SOURCES"); uris != "" {
+		providers, err := configsource.ProvidersFromURIs(cfg, uris)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts := make([]configsource.Option, len(providers))
+		for i, p := range providers {
+			opts[i] = configsource.WithProvider(p)
+		}
+		return configsource.NewResolver(opts...), nil, nil
+	}
+
+	var opts []configsource.Option
+	var ssmProvider *configsource.SSMProvider
+	if name := os.Getenv("OPENTELEMETRY_SSM_PARAMETER_NAME"); name != "" {
+		ssmProvider = configsource.NewSSMProvider(ssmClient, name)
+		opts = append(opts, configsource.WithProvider(ssmProvider))
+	} else {
+		opts = append(opts, configsource.WithProvider(configsource.NewFileProvider(defaultConfigFile())))
+	}
+	return configsource.NewResolver(opts...), ssmProvider, nil
+}
 
+// defaultConfigFile returns the local collector config path used when no
+// remote config source is configured.
+func defaultConfigFile() string {
 	val, ex := os.LookupEnv("OPENTELEMETRY_COLLECTOR_CONFIG_FILE")
 	if !ex {
 		return "/opt/collector-config/config.yaml"
 	}
-	log.Printf("Using config file at path %v", val)
+	logger.Info("Using config file at path", zap.String("path", val))
 	return val
 }
 
-func processEvents(ctx context.Context, collector *Collector) {
+// startOpAMPClient brings up the OpAMP client and wires its RemoteConfig
+// callback to swap ref's current collector for a freshly built one using the
+// same factories. ref is shared with the SSM hot-reload poll in
+// processEvents so the two paths never stop or replace each other's
+// collector out from under themselves.
+func startOpAMPClient(ctx context.Context, endpoint string, factories otelcol.Factories, ref *collectorRef) (*opampclient.Client, error) {
+	arn, err := functionARN(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("resolving function arn: %w", err)
+	}
+
+	return opampclient.New(endpoint, arn, func(ctx context.Context, configYAML []byte) error {
+		configYAML, err := injectLambdaResourceAttrs(ctx, factories, configYAML, logger)
+		if err != nil {
+			return fmt.Errorf("injecting lambda resource attributes: %w", err)
+		}
+
+		path := "/tmp/opamp_collector.yml"
+		if err := os.WriteFile(path, configYAML, 0o600); err != nil {
+			return fmt.Errorf("writing opamp config: %w", err)
+		}
+
+		if err := ref.swap(ctx, newCollector(factories, path)); err != nil {
+			return fmt.Errorf("starting collector with opamp config: %w", err)
+		}
+		return nil
+	}, opampclient.WithLogger(logger))
+}
+
+// functionARN resolves the ARN of the Lambda function the extension is
+// running alongside, used to derive a stable OpAMP InstanceUid.
+func functionARN(ctx context.Context) (string, error) {
+	cfg, err := awsConfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s", cfg.Region, aws.ToString(identity.Account), os.Getenv("AWS_LAMBDA_FUNCTION_NAME")), nil
+}
+
+func processEvents(ctx context.Context, ref *collectorRef, factories otelcol.Factories, ssmProvider *configsource.SSMProvider, statusReporter *status.Aggregator, selfInstrumentation *telemetry.SelfInstrumentation) {
+	refreshInterval := ssmRefreshInterval()
+	var lastPoll time.Time
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		default:
 			logger.Debug("Waiting for event...")
+			waitStart := time.Now()
 			res, err := extensionClient.NextEvent(ctx)
+			selfInstrumentation.RecordNextEventLatency(ctx, time.Since(waitStart))
 			if err != nil {
 				logln("Error:", err)
 				logln("Exiting")
@@ -124,11 +319,29 @@ func processEvents(ctx context.Context, collector *Collector) {
 			logger.Debug("Received ", zap.String("event :", prettyPrint(res)))
 			// Exit if we receive a SHUTDOWN event
 			if res.EventType == extension.Shutdown {
-				collector.Stop() // TODO: handle return values
+				for _, name := range componentNames(factories) {
+					statusReporter.ReportStatus(name, status.Stopping, nil)
+				}
+				ref.stop()
+				for _, name := range componentNames(factories) {
+					statusReporter.ReportStatus(name, status.Stopped, nil)
+				}
 				logger.Debug("Received SHUTDOWN event")
 				logger.Debug("Exiting")
 				return
 			}
+
+			// We're between invocations here, having just returned from one
+			// NextEvent call and about to block on the next: a safe window to
+			// poll SSM without competing with the function for frozen CPU.
+			if refreshInterval > 0 && ssmProvider != nil && time.Since(lastPoll) >= refreshInterval {
+				lastPoll = time.Now()
+				if reloaded, err := reloadIfConfigChanged(ctx, ssmProvider, factories, ref, logger); err != nil {
+					logger.Error("ssm refresh: failed to reload collector", zap.Error(err))
+				} else if reloaded {
+					logger.Info("ssm refresh: reloaded collector with updated config")
+				}
+			}
 		}
 	}
 }
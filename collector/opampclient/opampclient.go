@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opampclient implements an OpAMP (Open Agent Management Protocol)
+// client subsystem for the Lambda extension. When enabled, it connects to a
+// remote OpAMP server, exchanges agent identification and health, and applies
+// collector configuration pushed down as RemoteConfig messages.
+package opampclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+)
+
+// EnvEndpoint is the environment variable that activates the OpAMP client. The
+// extension falls back to its local SSM/file config resolution when unset.
+const EnvEndpoint = "OPENTELEMETRY_OPAMP_ENDPOINT"
+
+// EnvTransport selects the wire transport used to talk to the OpAMP server.
+// Defaults to "websocket"; set to "http" for VPC-restricted Lambdas where
+// long-lived inbound websocket connections aren't reachable and polling is
+// required instead.
+const EnvTransport = "OPENTELEMETRY_OPAMP_TRANSPORT"
+
+// newOpAMPClient returns the underlying opamp-go client for the configured
+// transport. Kept as a seam so the websocket transport can be swapped for
+// HTTP polling without touching the rest of the Client.
+func newOpAMPClient() client.OpAMPClient {
+	if os.Getenv(EnvTransport) == "http" {
+		return client.NewHTTP(nil)
+	}
+	return client.NewWebSocket(nil)
+}
+
+// ApplyConfigFunc rebuilds and restarts the embedded collector using the
+// effective configuration yaml received from the OpAMP server. It is expected
+// to stop the currently running collector before starting the new one.
+type ApplyConfigFunc func(ctx context.Context, configYAML []byte) error
+
+// Client manages the lifecycle of an OpAMP connection on behalf of the
+// extension: reporting identification and health, and applying RemoteConfig
+// messages as they arrive.
+type Client struct {
+	logger      *zap.Logger
+	opampClient client.OpAMPClient
+	applyConfig ApplyConfigFunc
+
+	mu            sync.Mutex
+	instanceUID   uuid.UUID
+	effectiveYAML []byte
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithLogger overrides the default no-op logger.
+func WithLogger(logger *zap.Logger) Option {
+	return func(c *Client) { c.logger = logger }
+}
+
+// New creates an OpAMP client that will connect to endpoint over the
+// transport selected by EnvTransport (websocket unless it is "http") and
+// invoke apply whenever a new RemoteConfig arrives.
+// functionARN identifies the Lambda function and is combined with a
+// disk-persisted UUID to form a stable InstanceUid across invocations of the
+// same function.
+func New(endpoint, functionARN string, apply ApplyConfigFunc, opts ...Option) (*Client, error) {
+	instanceUID, err := loadOrCreateInstanceUID(functionARN)
+	if err != nil {
+		return nil, fmt.Errorf("opampclient: resolving instance uid: %w", err)
+	}
+
+	c := &Client{
+		logger:      zap.NewNop(),
+		applyConfig: apply,
+		instanceUID: instanceUID,
+		opampClient: newOpAMPClient(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	settings := types.StartSettings{
+		OpAMPServerURL: endpoint,
+		InstanceUid:    types.InstanceUid(instanceUID),
+		Callbacks: types.CallbacksStruct{
+			OnConnectFunc: func(ctx context.Context) {
+				c.logger.Debug("opamp: connected")
+			},
+			OnConnectFailedFunc: func(ctx context.Context, err error) {
+				c.logger.Warn("opamp: connect failed", zap.Error(err))
+			},
+			OnMessageFunc: c.onMessage,
+			GetEffectiveConfigFunc: func(ctx context.Context) (*protobufs.EffectiveConfig, error) {
+				return c.buildEffectiveConfig(), nil
+			},
+			OnOpampConnectionSettingsFunc: func(ctx context.Context, settings *protobufs.OpAMPConnectionSettings) error {
+				return nil
+			},
+		},
+	}
+
+	if err := c.opampClient.Start(context.Background(), settings); err != nil {
+		return nil, fmt.Errorf("opampclient: starting client: %w", err)
+	}
+	return c, nil
+}
+
+// Stop disconnects from the OpAMP server.
+func (c *Client) Stop(ctx context.Context) error {
+	return c.opampClient.Stop(ctx)
+}
+
+// SetEffectiveConfig records the collector config currently running so it can
+// be reported back to the server via GetEffectiveConfigFunc.
+func (c *Client) SetEffectiveConfig(configYAML []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.effectiveYAML = configYAML
+}
+
+func (c *Client) buildEffectiveConfig() *protobufs.EffectiveConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &protobufs.EffectiveConfig{
+		ConfigMap: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: c.effectiveYAML, ContentType: "text/yaml"},
+			},
+		},
+	}
+}
+
+func (c *Client) onMessage(ctx context.Context, msg *types.MessageData) {
+	if msg.AgentIdentification != nil && len(msg.AgentIdentification.NewInstanceUid) > 0 {
+		var newUID uuid.UUID
+		copy(newUID[:], msg.AgentIdentification.NewInstanceUid)
+		c.mu.Lock()
+		c.instanceUID = newUID
+		c.mu.Unlock()
+		c.logger.Info("opamp: reassigned instance uid", zap.String("instance_uid", newUID.String()))
+	}
+
+	if msg.RemoteConfig == nil {
+		return
+	}
+
+	configYAML, ok := selectConfig(msg.RemoteConfig)
+	if !ok {
+		c.reportStatus(ctx, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, msg.RemoteConfig.ConfigHash, "no yaml config file present in RemoteConfig")
+		return
+	}
+
+	if err := c.applyConfig(ctx, configYAML); err != nil {
+		c.logger.Error("opamp: failed to apply remote config", zap.Error(err))
+		c.reportStatus(ctx, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, msg.RemoteConfig.ConfigHash, err.Error())
+		return
+	}
+
+	c.SetEffectiveConfig(configYAML)
+	c.reportStatus(ctx, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, msg.RemoteConfig.ConfigHash, "")
+	c.reportHealth(ctx, true, "")
+}
+
+// reportStatus tells the OpAMP server whether the RemoteConfig it sent was
+// applied. hash must be the ConfigHash off that same RemoteConfig message:
+// the server correlates status by echoing its own hash back, not one we
+// compute locally, so a self-computed hash would never match and the server
+// would treat the config as never applied.
+func (c *Client) reportStatus(_ context.Context, status protobufs.RemoteConfigStatuses, hash []byte, errMsg string) {
+	if err := c.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: hash,
+		Status:               status,
+		ErrorMessage:         errMsg,
+	}); err != nil {
+		c.logger.Warn("opamp: failed to report remote config status", zap.Error(err))
+	}
+}
+
+func (c *Client) reportHealth(_ context.Context, healthy bool, lastErr string) {
+	if err := c.opampClient.SetHealth(&protobufs.ComponentHealth{
+		Healthy:            healthy,
+		LastError:          lastErr,
+		StatusTimeUnixNano: uint64(time.Now().UnixNano()),
+	}); err != nil {
+		c.logger.Warn("opamp: failed to report health", zap.Error(err))
+	}
+}
+
+// selectConfig picks the collector yaml out of a RemoteConfig message. Config
+// servers are expected to key the file as "collector.yaml"; any single file
+// is accepted as a fallback for servers that don't name it.
+func selectConfig(rc *protobufs.AgentRemoteConfig) ([]byte, bool) {
+	if rc.Config == nil {
+		return nil, false
+	}
+	if f, ok := rc.Config.ConfigMap["collector.yaml"]; ok {
+		return f.Body, true
+	}
+	for _, f := range rc.Config.ConfigMap {
+		return f.Body, true
+	}
+	return nil, false
+}
+
+// loadOrCreateInstanceUID returns the InstanceUid reported to the OpAMP
+// server: a disk-persisted random UUID, namespaced to the Lambda function ARN
+// so two functions sharing an execution environment (unlikely, but possible
+// with SnapStart) never collide. The disk file survives for the lifetime of
+// the execution environment, so the instance uid is stable across the
+// invocations handled by a single sandbox.
+func loadOrCreateInstanceUID(functionARN string) (uuid.UUID, error) {
+	const path = "/tmp/opamp_instance_uid"
+
+	diskUUID, err := readOrCreatePersistedUUID(path)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	seed := append([]byte(functionARN), diskUUID[:]...)
+	return uuid.NewSHA1(uuid.NameSpaceOID, seed), nil
+}
+
+func readOrCreatePersistedUUID(path string) (uuid.UUID, error) {
+	if b, err := os.ReadFile(path); err == nil {
+		if id, err := uuid.Parse(string(b)); err == nil {
+			return id, nil
+		}
+	}
+
+	id := uuid.New()
+	if err := os.WriteFile(path, []byte(id.String()), 0o600); err != nil {
+		return uuid.UUID{}, fmt.Errorf("persisting instance uid: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// ListenAddr is where the status endpoint is served from. "sandbox" is the
+// hostname Lambda's own runtime/extension processes use to reach each other
+// inside the execution environment.
+const ListenAddr = "sandbox:2772"
+
+// TelemetryDestination is the URI Subscriber.Subscribe should be pointed at:
+// the /telemetry endpoint Serve exposes on ListenAddr to receive the
+// extension's own status lines back from the Lambda Extensions Telemetry
+// API as batched "extension" records.
+const TelemetryDestination = "http://" + ListenAddr + "/telemetry"
+
+// Serve starts an HTTP server exposing the aggregator's latest component
+// statuses at GET /status as JSON, so function code can check the health of
+// the pipeline it's about to emit telemetry through instead of relying on
+// collector logs that are easy to lose in CloudWatch. It also accepts
+// POST /telemetry, the destination Subscriber.Subscribe registers with the
+// Lambda Extensions Telemetry API, logging each delivered batch so a
+// subscription failure is visible rather than silently dropping telemetry.
+// It runs until ctx is cancelled.
+func Serve(ctx context.Context, aggregator *Aggregator) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(aggregator.Snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/telemetry", func(w http.ResponseWriter, r *http.Request) {
+		var records []json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&records); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		aggregator.logger.Debug("status: received telemetry api batch", zap.Int("records", len(records)))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := &http.Server{Addr: ListenAddr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
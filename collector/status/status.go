@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package status gives the components the embedded collector loads a
+// first-class way to report their lifecycle, analogous to the upstream
+// collector's ReportComponentStatus/StatusEvent API. Transitions are
+// aggregated in the extension process and exposed locally, both as
+// structured log lines - which Lambda classifies as "extension" telemetry
+// and delivers to any consumer subscribed via the Lambda Extensions
+// Telemetry API, see Subscribe - and over a local /status endpoint so
+// function code can poll for the health of the pipeline it's about to emit
+// telemetry through.
+package status
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Status is a component lifecycle state, mirroring the upstream collector's
+// componentstatus event types.
+type Status int
+
+const (
+	Starting Status = iota
+	OK
+	RecoverableError
+	PermanentError
+	Stopping
+	Stopped
+)
+
+func (s Status) String() string {
+	switch s {
+	case Starting:
+		return "Starting"
+	case OK:
+		return "OK"
+	case RecoverableError:
+		return "RecoverableError"
+	case PermanentError:
+		return "PermanentError"
+	case Stopping:
+		return "Stopping"
+	case Stopped:
+		return "Stopped"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a single component status transition.
+type Event struct {
+	Component string    `json:"component"`
+	Status    Status    `json:"-"`
+	StatusStr string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Reporter is handed to each receiver/processor/exporter factory registered
+// through lambdacomponents.Components() so the component can report its own
+// transitions without knowing how they're aggregated or forwarded.
+type Reporter interface {
+	ReportStatus(component string, s Status, err error)
+}
+
+// Aggregator collects status events from every component. The local /status
+// endpoint reads its snapshot; the Lambda Extensions Telemetry API receives
+// only the log lines it writes for each transition.
+type Aggregator struct {
+	logger *zap.Logger
+
+	mu     sync.RWMutex
+	latest map[string]Event
+}
+
+// NewAggregator builds an Aggregator that logs every transition through
+// logger using structured fields, so it's picked up by CloudWatch the same
+// way the rest of the extension's logs are.
+func NewAggregator(logger *zap.Logger) *Aggregator {
+	return &Aggregator{logger: logger, latest: make(map[string]Event)}
+}
+
+// ReportStatus implements Reporter.
+func (a *Aggregator) ReportStatus(component string, s Status, err error) {
+	event := Event{Component: component, Status: s, StatusStr: s.String(), Time: time.Now()}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	a.mu.Lock()
+	a.latest[component] = event
+	a.mu.Unlock()
+
+	fields := []zap.Field{
+		zap.String("component", component),
+		zap.String("status", s.String()),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	switch s {
+	case PermanentError:
+		a.logger.Error("component status", fields...)
+	case RecoverableError:
+		a.logger.Warn("component status", fields...)
+	default:
+		a.logger.Info("component status", fields...)
+	}
+}
+
+// Snapshot returns the most recent Event reported for each component.
+func (a *Aggregator) Snapshot() []Event {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	events := make([]Event, 0, len(a.latest))
+	for _, e := range a.latest {
+		events = append(events, e)
+	}
+	return events
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestAggregatorSnapshotReflectsLatestStatus(t *testing.T) {
+	agg := NewAggregator(zap.NewNop())
+
+	agg.ReportStatus("exporter/otlp", Starting, nil)
+	agg.ReportStatus("exporter/otlp", OK, nil)
+	agg.ReportStatus("exporter/otlp", RecoverableError, errors.New("connection refused"))
+
+	events := agg.Snapshot()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	got := events[0]
+	if got.StatusStr != RecoverableError.String() {
+		t.Errorf("status = %q, want %q", got.StatusStr, RecoverableError.String())
+	}
+	if got.Error != "connection refused" {
+		t.Errorf("error = %q, want %q", got.Error, "connection refused")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// subscribePath is the Lambda Extensions Telemetry API's subscription
+// endpoint, called against AWS_LAMBDA_RUNTIME_API.
+const subscribePath = "/2022-07-01/telemetry"
+
+// Subscriber registers the extension with the Lambda Extensions Telemetry
+// API so the component status lines Aggregator logs - which
+// Lambda classifies as "extension" telemetry - are delivered, batched, to
+// destination alongside platform and function telemetry.
+type Subscriber struct {
+	runtimeAPI  string
+	extensionID string
+	client      *http.Client
+}
+
+// NewSubscriber returns a Subscriber that authenticates to runtimeAPI's
+// Telemetry API as extensionID, the id returned from the extension's
+// Register call.
+func NewSubscriber(runtimeAPI, extensionID string) *Subscriber {
+	return &Subscriber{runtimeAPI: runtimeAPI, extensionID: extensionID, client: http.DefaultClient}
+}
+
+// Subscribe registers destination (an http://host:port/path URI, typically
+// this extension's own /telemetry endpoint) to receive "extension" type
+// telemetry, which is how the component status lines Aggregator logs reach
+// it. See Serve for the endpoint that receives the resulting batches.
+func (s *Subscriber) Subscribe(ctx context.Context, destination string) error {
+	body, err := json.Marshal(map[string]any{
+		"schemaVersion": "2022-07-01",
+		"types":         []string{"extension"},
+		"buffering": map[string]int{
+			"maxItems":  1000,
+			"maxBytes":  262144,
+			"timeoutMs": 100,
+		},
+		"destination": map[string]string{
+			"protocol": "HTTP",
+			"URI":      destination,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("status: building telemetry api subscription request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "http://"+s.runtimeAPI+subscribePath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("status: building telemetry api subscription request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Lambda-Extension-Identifier", s.extensionID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("status: subscribing to telemetry api: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status: telemetry api subscribe returned status %d", resp.StatusCode)
+	}
+	return nil
+}
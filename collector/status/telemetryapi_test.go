@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSubscriberSubscribeSendsExpectedRequest(t *testing.T) {
+	var gotPath, gotExtensionID string
+	var gotBody map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotExtensionID = r.Header.Get("Lambda-Extension-Identifier")
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decoding subscribe request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	subscriber := NewSubscriber(strings.TrimPrefix(server.URL, "http://"), "ext-123")
+	if err := subscriber.Subscribe(context.Background(), TelemetryDestination); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	if gotPath != subscribePath {
+		t.Errorf("path = %q, want %q", gotPath, subscribePath)
+	}
+	if gotExtensionID != "ext-123" {
+		t.Errorf("Lambda-Extension-Identifier = %q, want %q", gotExtensionID, "ext-123")
+	}
+	dest, _ := gotBody["destination"].(map[string]any)
+	if dest["URI"] != TelemetryDestination {
+		t.Errorf("destination URI = %v, want %q", dest["URI"], TelemetryDestination)
+	}
+}
+
+func TestSubscriberSubscribeReturnsErrorOnNonOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	subscriber := NewSubscriber(strings.TrimPrefix(server.URL, "http://"), "ext-123")
+	if err := subscriber.Subscribe(context.Background(), TelemetryDestination); err == nil {
+		t.Fatal("expected an error for a non-200 response, got nil")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// WrapFactories returns a copy of factories whose receiver, processor, and
+// exporter factories report the Start/Shutdown transitions of the
+// components they build to reporter. This is what lets something like an
+// OTLP exporter failing to reach its backend from inside a VPC surface as a
+// RecoverableError, instead of only the coarse Starting/Stopping/Stopped
+// the extension reports around the collector as a whole.
+func WrapFactories(factories otelcol.Factories, reporter Reporter) otelcol.Factories {
+	wrapped := factories
+
+	receivers := make(map[component.Type]receiver.Factory, len(factories.Receivers))
+	for t, f := range factories.Receivers {
+		receivers[t] = wrapReceiverFactory(f, reporter)
+	}
+	wrapped.Receivers = receivers
+
+	processors := make(map[component.Type]processor.Factory, len(factories.Processors))
+	for t, f := range factories.Processors {
+		processors[t] = wrapProcessorFactory(f, reporter)
+	}
+	wrapped.Processors = processors
+
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for t, f := range factories.Exporters {
+		exporters[t] = wrapExporterFactory(f, reporter)
+	}
+	wrapped.Exporters = exporters
+
+	return wrapped
+}
+
+// reportingComponent wraps a component.Component so its Start/Shutdown
+// transitions are reported under name instead of disappearing into whatever
+// the component itself logs.
+type reportingComponent struct {
+	component.Component
+	name     string
+	reporter Reporter
+}
+
+func (r *reportingComponent) Start(ctx context.Context, host component.Host) error {
+	if err := r.Component.Start(ctx, host); err != nil {
+		r.reporter.ReportStatus(r.name, RecoverableError, err)
+		return err
+	}
+	r.reporter.ReportStatus(r.name, OK, nil)
+	return nil
+}
+
+func (r *reportingComponent) Shutdown(ctx context.Context) error {
+	r.reporter.ReportStatus(r.name, Stopping, nil)
+	if err := r.Component.Shutdown(ctx); err != nil {
+		r.reporter.ReportStatus(r.name, RecoverableError, err)
+		return err
+	}
+	r.reporter.ReportStatus(r.name, Stopped, nil)
+	return nil
+}
+
+func wrapReceiverFactory(f receiver.Factory, reporter Reporter) receiver.Factory {
+	name := "receiver/" + f.Type().String()
+	return receiver.NewFactory(
+		f.Type(),
+		f.CreateDefaultConfig,
+		receiver.WithTraces(func(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+			c, err := f.CreateTraces(ctx, set, cfg, next)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.TracesStability()),
+		receiver.WithMetrics(func(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+			c, err := f.CreateMetrics(ctx, set, cfg, next)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.MetricsStability()),
+		receiver.WithLogs(func(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+			c, err := f.CreateLogs(ctx, set, cfg, next)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.LogsStability()),
+	)
+}
+
+func wrapProcessorFactory(f processor.Factory, reporter Reporter) processor.Factory {
+	name := "processor/" + f.Type().String()
+	return processor.NewFactory(
+		f.Type(),
+		f.CreateDefaultConfig,
+		processor.WithTraces(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			c, err := f.CreateTraces(ctx, set, cfg, next)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.TracesStability()),
+		processor.WithMetrics(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+			c, err := f.CreateMetrics(ctx, set, cfg, next)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.MetricsStability()),
+		processor.WithLogs(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+			c, err := f.CreateLogs(ctx, set, cfg, next)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.LogsStability()),
+	)
+}
+
+func wrapExporterFactory(f exporter.Factory, reporter Reporter) exporter.Factory {
+	name := "exporter/" + f.Type().String()
+	return exporter.NewFactory(
+		f.Type(),
+		f.CreateDefaultConfig,
+		exporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			c, err := f.CreateTraces(ctx, set, cfg)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.TracesStability()),
+		exporter.WithMetrics(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+			c, err := f.CreateMetrics(ctx, set, cfg)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.MetricsStability()),
+		exporter.WithLogs(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+			c, err := f.CreateLogs(ctx, set, cfg)
+			if err != nil {
+				reporter.ReportStatus(name, RecoverableError, err)
+				return nil, err
+			}
+			return &reportingComponent{Component: c, name: name, reporter: reporter}, nil
+		}, f.LogsStability()),
+	)
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// grpcLoggerV2 adapts a *zap.Logger to grpclog.LoggerV2 so gRPC's internal
+// logging (used by OTLP exporters) flows through the same sink as the rest
+// of the extension's logs rather than writing to stderr on its own.
+type grpcLoggerV2 struct {
+	logger *zap.Logger
+}
+
+func newGRPCLoggerV2(logger *zap.Logger) *grpcLoggerV2 {
+	return &grpcLoggerV2{logger: logger}
+}
+
+func (g *grpcLoggerV2) Info(args ...interface{})   { g.logger.Info(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Infoln(args ...interface{}) { g.logger.Info(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Infof(format string, args ...interface{}) {
+	g.logger.Info(fmt.Sprintf(format, args...))
+}
+func (g *grpcLoggerV2) Warning(args ...interface{})   { g.logger.Warn(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Warningln(args ...interface{}) { g.logger.Warn(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Warningf(format string, args ...interface{}) {
+	g.logger.Warn(fmt.Sprintf(format, args...))
+}
+func (g *grpcLoggerV2) Error(args ...interface{})   { g.logger.Error(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Errorln(args ...interface{}) { g.logger.Error(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Errorf(format string, args ...interface{}) {
+	g.logger.Error(fmt.Sprintf(format, args...))
+}
+func (g *grpcLoggerV2) Fatal(args ...interface{})   { g.logger.Fatal(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Fatalln(args ...interface{}) { g.logger.Fatal(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Fatalf(format string, args ...interface{}) {
+	g.logger.Fatal(fmt.Sprintf(format, args...))
+}
+func (g *grpcLoggerV2) V(level int) bool { return level == 0 }
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry owns construction of the extension's own logger, tracer,
+// and meter, so every piece of telemetry the extension emits about itself -
+// as opposed to the telemetry the embedded collector pipelines handle on the
+// function's behalf - goes through one consistent pipeline instead of the
+// ad-hoc zap.NewExample()/log.Fatalf calls main.go used to make directly.
+package telemetry
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/grpclog"
+)
+
+// EnvLogLevel selects the extension logger's level: debug, info, warn, or
+// error. Defaults to info.
+const EnvLogLevel = "OPENTELEMETRY_EXTENSION_LOG_LEVEL"
+
+// EnvLogEncoding selects the extension logger's encoding: json or console.
+// Defaults to json, since extension stdout is shipped to CloudWatch as log
+// lines rather than read on a terminal.
+const EnvLogEncoding = "OPENTELEMETRY_EXTENSION_LOG_ENCODING"
+
+// NewLogger builds the extension's zap logger from EnvLogLevel and
+// EnvLogEncoding, and installs it as the grpc/grpclog logger so the gRPC
+// framework logging pulled in by OTLP exporters follows the same pipeline
+// instead of writing directly to stderr. The grpc logger is clamped to WARN
+// whenever the collector's own level is INFO or more verbose, since gRPC
+// logs one INFO line per request and would otherwise drown out the
+// extension's own logs.
+func NewLogger() (*zap.Logger, error) {
+	level := parseLevel(os.Getenv(EnvLogLevel))
+
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(level)
+	cfg.Encoding = parseEncoding(os.Getenv(EnvLogEncoding))
+
+	logger, err := cfg.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	installGRPCLogger(logger, level)
+	return logger, nil
+}
+
+func parseLevel(v string) zapcore.Level {
+	switch v {
+	case "debug":
+		return zapcore.DebugLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+func parseEncoding(v string) string {
+	if v == "console" {
+		return "console"
+	}
+	return "json"
+}
+
+// installGRPCLogger wires logger into grpclog, clamped to WARN when level is
+// INFO or more verbose so the per-RPC logging the gRPC transport does for
+// OTLP exporters doesn't spam over the extension's own logs.
+func installGRPCLogger(logger *zap.Logger, level zapcore.Level) {
+	grpcLevel := level
+	if grpcLevel <= zapcore.InfoLevel {
+		grpcLevel = zapcore.WarnLevel
+	}
+	grpclog.SetLoggerV2(newGRPCLoggerV2(logger.WithOptions(zap.IncreaseLevel(grpcLevel))))
+}
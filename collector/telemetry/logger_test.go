@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]zapcore.Level{
+		"debug": zapcore.DebugLevel,
+		"warn":  zapcore.WarnLevel,
+		"error": zapcore.ErrorLevel,
+		"info":  zapcore.InfoLevel,
+		"":      zapcore.InfoLevel,
+		"bogus": zapcore.InfoLevel,
+	}
+	for in, want := range cases {
+		if got := parseLevel(in); got != want {
+			t.Errorf("parseLevel(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	if got := parseEncoding("console"); got != "console" {
+		t.Errorf("parseEncoding(console) = %q, want console", got)
+	}
+	if got := parseEncoding(""); got != "json" {
+		t.Errorf("parseEncoding(\"\") = %q, want json", got)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationScope identifies spans and metrics SelfInstrumentation
+// emits, independent of whatever scope the embedded collector's own
+// pipelines use for the function's telemetry.
+const instrumentationScope = "github.com/open-telemetry/opentelemetry-lambda/collector"
+
+// SelfInstrumentation wraps the tracer and meter the extension uses to
+// report on its own event loop: registering with the Extensions API,
+// waiting on NextEvent, and shutting down. It owns its own SDK
+// TracerProvider/MeterProvider rather than relying on the global
+// otel.Tracer/otel.Meter, which are no-ops unless something else in the
+// process happens to install a provider - so self-instrumentation always
+// actually emits, and stays independent of whatever tracer/meter providers
+// the embedded collector's own pipelines construct for the function's
+// telemetry.
+type SelfInstrumentation struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+
+	tracer trace.Tracer
+	meter  metric.Meter
+
+	nextEventLatency metric.Float64Histogram
+}
+
+// NewSelfInstrumentation builds a SelfInstrumentation backed by its own SDK
+// tracer/meter providers, exporting via stdout so spans and metrics are
+// visible in the extension's own logs without depending on the embedded
+// collector's pipeline being configured to accept them.
+func NewSelfInstrumentation() (*SelfInstrumentation, error) {
+	traceExporter, err := stdouttrace.New()
+	if err != nil {
+		return nil, fmt.Errorf("self-instrumentation: building trace exporter: %w", err)
+	}
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter))
+
+	metricExporter, err := stdoutmetric.New()
+	if err != nil {
+		return nil, fmt.Errorf("self-instrumentation: building metric exporter: %w", err)
+	}
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)))
+
+	tracer := tracerProvider.Tracer(instrumentationScope)
+	meter := meterProvider.Meter(instrumentationScope)
+
+	nextEventLatency, err := meter.Float64Histogram(
+		"lambda_extension.next_event.duration",
+		metric.WithDescription("Time spent blocked waiting for the next Extensions API event"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SelfInstrumentation{
+		tracerProvider:   tracerProvider,
+		meterProvider:    meterProvider,
+		tracer:           tracer,
+		meter:            meter,
+		nextEventLatency: nextEventLatency,
+	}, nil
+}
+
+// StartRegister starts a span around the extension's Register call.
+func (s *SelfInstrumentation) StartRegister(ctx context.Context) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "lambda_extension.register")
+}
+
+// RecordNextEventLatency records how long the extension blocked in
+// NextEvent before an event arrived.
+func (s *SelfInstrumentation) RecordNextEventLatency(ctx context.Context, waited time.Duration) {
+	s.nextEventLatency.Record(ctx, waited.Seconds())
+}
+
+// StartShutdown starts a span around the extension's shutdown handling.
+func (s *SelfInstrumentation) StartShutdown(ctx context.Context) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "lambda_extension.shutdown")
+}
+
+// Shutdown flushes and stops the tracer and meter providers, so spans and
+// metrics recorded during the extension's final shutdown aren't lost.
+func (s *SelfInstrumentation) Shutdown(ctx context.Context) error {
+	if err := s.tracerProvider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("self-instrumentation: shutting down tracer provider: %w", err)
+	}
+	if err := s.meterProvider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("self-instrumentation: shutting down meter provider: %w", err)
+	}
+	return nil
+}